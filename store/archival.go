@@ -0,0 +1,46 @@
+package store
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+)
+
+// ArchivalStore mirrors dispersed blobs to a secondary archival backend —
+// WVM, Arweave, S3, IPFS, or another EVM-chain calldata sink — keyed by the
+// EigenDA certificate that was dispersed, so they can still be served if
+// EigenDA itself becomes unavailable.
+type ArchivalStore interface {
+	// Mirror persists encodedBlob, archived against cert.
+	Mirror(ctx context.Context, cert *verify.Certificate, encodedBlob []byte) error
+	// Lookup retrieves the still-encoded blob archived for cert.
+	Lookup(ctx context.Context, cert *verify.Certificate) ([]byte, error)
+	// TxRef returns this backend's transaction/object reference for cert
+	// (e.g. a WVM transaction hash or an S3 object key).
+	TxRef(ctx context.Context, cert *verify.Certificate) (string, error)
+}
+
+// wvmArchivalStore adapts a *WVMClient to ArchivalStore so WVM is just one of
+// potentially several configured archival mirrors rather than a hardcoded
+// field on EigenDAStore.
+type wvmArchivalStore struct {
+	client *WVMClient
+}
+
+var _ ArchivalStore = (*wvmArchivalStore)(nil)
+
+func (w *wvmArchivalStore) Mirror(ctx context.Context, cert *verify.Certificate, encodedBlob []byte) error {
+	return w.client.Store(ctx, cert, encodedBlob)
+}
+
+func (w *wvmArchivalStore) Lookup(ctx context.Context, cert *verify.Certificate) ([]byte, error) {
+	txHash, err := w.client.GetWvmTxHashByCommitment(ctx, cert)
+	if err != nil {
+		return nil, err
+	}
+	return w.client.GetBlobFromWvm(ctx, txHash)
+}
+
+func (w *wvmArchivalStore) TxRef(ctx context.Context, cert *verify.Certificate) (string, error) {
+	return w.client.GetWvmTxHashByCommitment(ctx, cert)
+}
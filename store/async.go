@@ -0,0 +1,514 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// PutState describes where an async dispersal job is in its lifecycle.
+type PutState uint8
+
+const (
+	// Dispersing means PutBlob and commitment verification have succeeded,
+	// but no VerifyCert attempt has yet succeeded or reported
+	// ErrBatchMetadataHashNotFound.
+	Dispersing PutState = iota
+	// Confirmed means the blob has been included in a batch, but has not
+	// yet reached cfg.EthConfirmationDepth.
+	Confirmed
+	// Finalized means VerifyCert succeeded at the required confirmation
+	// depth and the blob has been mirrored to every registered archive.
+	Finalized
+	Failed
+)
+
+func (s PutState) String() string {
+	switch s {
+	case Dispersing:
+		return "Dispersing"
+	case Confirmed:
+		return "Confirmed"
+	case Finalized:
+		return "Finalized"
+	case Failed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// putJob tracks one in-flight PutAsync dispersal from submission through
+// finalization. Created/Deadline are Unix nanoseconds rather than time.Time
+// so putJob stays RLP-encodable for JobStore implementations that persist it
+// (RLP has no encoding for time.Time).
+type putJob struct {
+	State              PutState
+	Cert               *verify.Certificate
+	Version            BlobEncodingVersion
+	EncodedBlob        []byte
+	CreatedUnixNs      int64
+	DeadlineUnixNs     int64
+	NextAttemptUnixNs  int64
+	VerifyCertAttempts int
+	Err                string
+}
+
+func (j *putJob) created() time.Time     { return time.Unix(0, j.CreatedUnixNs) }
+func (j *putJob) deadline() time.Time    { return time.Unix(0, j.DeadlineUnixNs) }
+func (j *putJob) nextAttempt() time.Time { return time.Unix(0, j.NextAttemptUnixNs) }
+
+// JobStore persists in-flight PutAsync jobs, keyed by the job ID PutAsync
+// returned, so a restarted proxy can resume ticking them toward finality
+// instead of losing track of pending dispersals.
+type JobStore interface {
+	SaveJob(ctx context.Context, jobID []byte, job *putJob) error
+	LoadJob(ctx context.Context, jobID []byte) (*putJob, error)
+	DeleteJob(ctx context.Context, jobID []byte) error
+	ListPendingJobIDs(ctx context.Context) ([][]byte, error)
+}
+
+// memoryJobStore is the default JobStore: an in-process map. It does not
+// survive a restart; pass a JobStore backed by Redis or S3 to
+// EigenDAStore.WithJobStore to do so.
+type memoryJobStore struct {
+	mu   sync.Mutex
+	jobs map[string]*putJob
+}
+
+// NewMemoryJobStore returns a JobStore that keeps jobs in memory only.
+func NewMemoryJobStore() JobStore {
+	return &memoryJobStore{jobs: make(map[string]*putJob)}
+}
+
+func (m *memoryJobStore) SaveJob(_ context.Context, jobID []byte, job *putJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[string(jobID)] = job
+	return nil
+}
+
+func (m *memoryJobStore) LoadJob(_ context.Context, jobID []byte) (*putJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[string(jobID)]
+	if !ok {
+		return nil, fmt.Errorf("no job found for job ID")
+	}
+	// Return a copy: the caller mutates and advances the job's state without
+	// holding m.mu, and must not do so on the struct still referenced by m.jobs.
+	cp := *job
+	return &cp, nil
+}
+
+func (m *memoryJobStore) DeleteJob(_ context.Context, jobID []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.jobs, string(jobID))
+	return nil
+}
+
+func (m *memoryJobStore) ListPendingJobIDs(_ context.Context) ([][]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([][]byte, 0, len(m.jobs))
+	for id, job := range m.jobs {
+		if job.State == Dispersing || job.State == Confirmed {
+			ids = append(ids, []byte(id))
+		}
+	}
+	return ids, nil
+}
+
+// precomputedJobIndexKey is the backend key under which precomputedJobStore
+// persists the set of job IDs it's tracking, so the index itself survives a
+// proxy restart rather than just the jobs it points at.
+var precomputedJobIndexKey = []byte("__eigenda_job_index__")
+
+// precomputedJobStore persists jobs through an arbitrary PrecomputedKeyStore
+// (e.g. the Redis or S3 backend), RLP-encoding each putJob as the value.
+// index is an in-memory mirror of the job IDs stored under
+// precomputedJobIndexKey, loaded from backend at construction and persisted
+// back on every Save/Delete, so it (and therefore ListPendingJobIDs) survives
+// a restart instead of resetting to empty.
+type precomputedJobStore struct {
+	mu      sync.Mutex
+	backend PrecomputedKeyStore
+	index   map[string]struct{}
+}
+
+// NewPrecomputedJobStore returns a JobStore backed by backend, so pending
+// jobs survive a proxy restart. It loads any index already persisted by a
+// prior instance of this JobStore before a backend has any jobs in it.
+func NewPrecomputedJobStore(backend PrecomputedKeyStore) JobStore {
+	p := &precomputedJobStore{backend: backend, index: make(map[string]struct{})}
+	if raw, err := backend.Get(context.Background(), precomputedJobIndexKey); err == nil {
+		var ids [][]byte
+		if err := rlp.DecodeBytes(raw, &ids); err == nil {
+			for _, id := range ids {
+				p.index[string(id)] = struct{}{}
+			}
+		}
+	}
+	return p
+}
+
+func (p *precomputedJobStore) SaveJob(ctx context.Context, jobID []byte, job *putJob) error {
+	raw, err := rlp.EncodeToBytes(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode job to RLP format: %w", err)
+	}
+	if err := p.backend.Put(ctx, jobID, raw); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.index[string(jobID)] = struct{}{}
+	return p.saveIndexLocked(ctx)
+}
+
+func (p *precomputedJobStore) LoadJob(ctx context.Context, jobID []byte) (*putJob, error) {
+	raw, err := p.backend.Get(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	var job putJob
+	if err := rlp.DecodeBytes(raw, &job); err != nil {
+		return nil, fmt.Errorf("failed to decode job from RLP format: %w", err)
+	}
+	return &job, nil
+}
+
+func (p *precomputedJobStore) DeleteJob(ctx context.Context, jobID []byte) error {
+	if err := p.backend.Delete(ctx, jobID); err != nil {
+		return fmt.Errorf("failed to delete job from backend: %w", err)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.index, string(jobID))
+	return p.saveIndexLocked(ctx)
+}
+
+// saveIndexLocked persists p.index to backend under precomputedJobIndexKey.
+// Callers must hold p.mu.
+func (p *precomputedJobStore) saveIndexLocked(ctx context.Context) error {
+	ids := make([][]byte, 0, len(p.index))
+	for id := range p.index {
+		ids = append(ids, []byte(id))
+	}
+	raw, err := rlp.EncodeToBytes(ids)
+	if err != nil {
+		return fmt.Errorf("failed to encode job index to RLP format: %w", err)
+	}
+	if err := p.backend.Put(ctx, precomputedJobIndexKey, raw); err != nil {
+		return fmt.Errorf("failed to persist job index: %w", err)
+	}
+	return nil
+}
+
+func (p *precomputedJobStore) ListPendingJobIDs(ctx context.Context) ([][]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ids := make([][]byte, 0, len(p.index))
+	for id := range p.index {
+		job, err := p.LoadJob(ctx, []byte(id))
+		if err != nil {
+			continue
+		}
+		if job.State == Dispersing || job.State == Confirmed {
+			ids = append(ids, []byte(id))
+		}
+	}
+	return ids, nil
+}
+
+// PutAsync disperses value and returns a job ID as soon as PutBlob and
+// commitment verification succeed, without blocking on confirmation depth.
+// Poll PutStatus with the returned job ID to learn when the blob reaches
+// Finalized.
+func (e EigenDAStore) PutAsync(ctx context.Context, value []byte) ([]byte, error) {
+	return e.PutAsyncWithVersion(ctx, value, e.cfg.DefaultBlobEncodingVersion)
+}
+
+// PutAsyncWithVersion is PutAsync, encoding value with the codec registered
+// for version.
+func (e EigenDAStore) PutAsyncWithVersion(ctx context.Context, value []byte, version BlobEncodingVersion) ([]byte, error) {
+	codec, err := codecForVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	encodeStart := time.Now()
+	encodedBlob, err := codec.EncodeBlob(value)
+	if err != nil {
+		return nil, fmt.Errorf("EigenDA client failed to re-encode blob: %w", err)
+	}
+	e.metrics.observePutDuration(phaseEncode, time.Since(encodeStart))
+	e.metrics.observeBlobBytes(len(encodedBlob))
+
+	if uint64(len(encodedBlob)) > e.cfg.MaxBlobSizeBytes {
+		return nil, fmt.Errorf("encoded blob is larger than max blob size: blob length %d, encoded blob length: %d, max blob size %d", len(value), len(encodedBlob), e.cfg.MaxBlobSizeBytes)
+	}
+
+	dispersalStart := time.Now()
+	blobInfo, err := e.client.PutBlob(ctx, value)
+	if err != nil {
+		e.metrics.recordError(err)
+		return nil, err
+	}
+	e.metrics.observePutDuration(phaseDisperse, time.Since(dispersalStart))
+	cert := (*verify.Certificate)(blobInfo)
+
+	verifyCommitmentStart := time.Now()
+	if err := e.verifier.VerifyCommitment(cert.BlobHeader.Commitment, encodedBlob); err != nil {
+		e.metrics.recordError(err)
+		return nil, err
+	}
+	e.metrics.observePutDuration(phaseVerifyCommitment, time.Since(verifyCommitmentStart))
+
+	jobID, err := rlp.EncodeToBytes(&encodedCertificate{Cert: *cert, Version: version})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode DA cert to RLP format: %w", err)
+	}
+
+	job := &putJob{
+		State:          Dispersing,
+		Cert:           cert,
+		Version:        version,
+		EncodedBlob:    encodedBlob,
+		CreatedUnixNs:  time.Now().UnixNano(),
+		DeadlineUnixNs: time.Now().Add(e.cfg.StatusQueryTimeout).UnixNano(),
+	}
+	if err := e.jobs.SaveJob(ctx, jobID, job); err != nil {
+		return nil, fmt.Errorf("failed to persist async put job: %w", err)
+	}
+
+	atomic.AddInt64(e.entries, 1)
+	return jobID, nil
+}
+
+// PutStatus reports the current state of a job previously started with
+// PutAsync.
+func (e EigenDAStore) PutStatus(ctx context.Context, jobID []byte) (PutState, *verify.Certificate, error) {
+	job, err := e.jobs.LoadJob(ctx, jobID)
+	if err != nil {
+		return Failed, nil, err
+	}
+	if job.State == Failed {
+		return Failed, job.Cert, errors.New(job.Err)
+	}
+	return job.State, job.Cert, nil
+}
+
+// RunAsyncWorker ticks every interval, advancing every pending PutAsync job
+// at most one step toward Finalized, until ctx is canceled. Callers should
+// run it in its own goroutine; a single worker serves every job rather than
+// one goroutine per Put.
+func (e EigenDAStore) RunAsyncWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.advancePendingJobs(ctx)
+		}
+	}
+}
+
+func (e EigenDAStore) advancePendingJobs(ctx context.Context) {
+	jobIDs, err := e.jobs.ListPendingJobIDs(ctx)
+	if err != nil {
+		e.log.Error("failed to list pending async put jobs", "err", err)
+		return
+	}
+
+	for _, jobID := range jobIDs {
+		e.advanceJobByID(ctx, jobID)
+	}
+}
+
+// lockJob returns jobID's dedicated mutex, creating one if this is the first
+// time it's been advanced, and locks it. Call the returned unlock func to
+// release it.
+func (e EigenDAStore) lockJob(jobID []byte) func() {
+	actual, _ := e.jobLocks.LoadOrStore(string(jobID), &sync.Mutex{})
+	mu := actual.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// advanceJobByID loads jobID and advances it at most one step, serialized
+// against every other caller (RunAsyncWorker's sweep or a blocking Put's own
+// poll) advancing the same job ID, so they can never race on its mutable
+// state or double-call an archive.Mirror for it.
+func (e EigenDAStore) advanceJobByID(ctx context.Context, jobID []byte) {
+	unlock := e.lockJob(jobID)
+	defer unlock()
+
+	job, err := e.jobs.LoadJob(ctx, jobID)
+	if err != nil {
+		e.log.Error("failed to load async put job", "err", err)
+		return
+	}
+	if job.State != Dispersing && job.State != Confirmed {
+		return
+	}
+	e.advanceJob(ctx, jobID, job)
+}
+
+// newBackOff builds the retry/backoff policy for a single job's VerifyCert
+// schedule, using cfg.NewVerifyCertBackOff if set.
+func (e EigenDAStore) newBackOff() backoff.BackOff {
+	if e.cfg.NewVerifyCertBackOff != nil {
+		return e.cfg.NewVerifyCertBackOff()
+	}
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 2 * time.Second
+	b.MaxInterval = 12 * time.Second
+	b.MaxElapsedTime = 0 // bounded by MaxVerifyCertRetries and job.Deadline instead
+	return b
+}
+
+// backOffFor returns jobID's in-flight backoff.BackOff, creating one if this
+// is the first time it's been advanced.
+func (e EigenDAStore) backOffFor(jobID []byte) backoff.BackOff {
+	key := string(jobID)
+	if b, ok := e.backoffs.Load(key); ok {
+		return b.(backoff.BackOff)
+	}
+	b := e.newBackOff()
+	e.backoffs.Store(key, b)
+	return b
+}
+
+// resetBackOff replaces jobID's backoff with a fresh one. Called when
+// ErrBatchMetadataHashNotFound is seen: the blob was seen by EigenDA, so
+// we're only waiting on confirmation depth rather than recovering from a
+// transient failure, and should keep polling promptly.
+func (e EigenDAStore) resetBackOff(jobID []byte) backoff.BackOff {
+	b := e.newBackOff()
+	e.backoffs.Store(string(jobID), b)
+	return b
+}
+
+func (e EigenDAStore) clearBackOff(jobID []byte) {
+	e.backoffs.Delete(string(jobID))
+}
+
+// clearJobLock drops jobID's entry from jobLocks once it's reached a
+// terminal state, so jobLocks doesn't grow by one entry per Put/PutAsync
+// call for the life of the process. Safe to call while still holding the
+// mutex being removed: it only unlinks the map entry, it doesn't touch the
+// mutex itself.
+func (e EigenDAStore) clearJobLock(jobID []byte) {
+	e.jobLocks.Delete(string(jobID))
+}
+
+func (e EigenDAStore) advanceJob(ctx context.Context, jobID []byte, job *putJob) {
+	if time.Now().After(job.deadline()) {
+		job.State = Failed
+		job.Err = "timed out when trying to verify the DA certificate for a blob batch after dispersal"
+		e.saveJob(ctx, jobID, job)
+		e.clearBackOff(jobID)
+		e.clearJobLock(jobID)
+		e.scheduleJobCleanup(jobID)
+		return
+	}
+	if time.Now().Before(job.nextAttempt()) {
+		return
+	}
+	if max := e.cfg.MaxVerifyCertRetries; max > 0 && job.VerifyCertAttempts >= max {
+		job.State = Failed
+		job.Err = fmt.Sprintf("exceeded max VerifyCert retries (%d)", max)
+		e.saveJob(ctx, jobID, job)
+		e.clearBackOff(jobID)
+		e.clearJobLock(jobID)
+		e.scheduleJobCleanup(jobID)
+		return
+	}
+
+	verifyCertStart := time.Now()
+	err := e.verifier.VerifyCert(job.Cert)
+	e.metrics.observePutDuration(phaseVerifyCert, time.Since(verifyCertStart))
+	job.VerifyCertAttempts++
+
+	switch {
+	case err == nil:
+		mirrorStart := time.Now()
+		for name, archive := range e.archives {
+			if archErr := archive.Mirror(ctx, job.Cert, job.EncodedBlob); archErr != nil {
+				e.metrics.recordError(archErr)
+				job.State = Failed
+				job.Err = fmt.Errorf("failed to mirror blob to %s archive: %w", name, archErr).Error()
+				e.saveJob(ctx, jobID, job)
+				e.clearBackOff(jobID)
+				e.clearJobLock(jobID)
+				e.scheduleJobCleanup(jobID)
+				return
+			}
+		}
+		e.metrics.observePutDuration(phaseWvmMirror, time.Since(mirrorStart))
+		e.metrics.observeConfirmationDepthWait(time.Since(job.created()))
+		job.State = Finalized
+		e.saveJob(ctx, jobID, job)
+		e.clearBackOff(jobID)
+		e.clearJobLock(jobID)
+		e.scheduleJobCleanup(jobID)
+	case errors.Is(err, verify.ErrBatchMetadataHashNotFound):
+		e.metrics.incVerifyCertRetry()
+		e.metrics.recordError(err)
+		next := e.resetBackOff(jobID).NextBackOff()
+		job.State = Confirmed
+		job.NextAttemptUnixNs = time.Now().Add(next).UnixNano()
+		e.saveJob(ctx, jobID, job)
+	default:
+		e.metrics.recordError(err)
+		next := e.backOffFor(jobID).NextBackOff()
+		if next == backoff.Stop {
+			job.State = Failed
+			job.Err = err.Error()
+			e.saveJob(ctx, jobID, job)
+			e.clearBackOff(jobID)
+			e.clearJobLock(jobID)
+			e.scheduleJobCleanup(jobID)
+			return
+		}
+		job.NextAttemptUnixNs = time.Now().Add(next).UnixNano()
+		e.saveJob(ctx, jobID, job)
+	}
+}
+
+// jobRetentionAfterTerminal is how long a Finalized/Failed job is kept in the
+// JobStore after reaching a terminal state, so a PutStatus poll racing the
+// terminal transition still finds it rather than erroring with "no job
+// found".
+const jobRetentionAfterTerminal = 10 * time.Minute
+
+// scheduleJobCleanup deletes jobID from the JobStore after
+// jobRetentionAfterTerminal, once it's reached a terminal state. Without
+// this, every PutAsync/Put permanently grows the JobStore for the life of
+// the process.
+func (e EigenDAStore) scheduleJobCleanup(jobID []byte) {
+	id := append([]byte(nil), jobID...)
+	time.AfterFunc(jobRetentionAfterTerminal, func() {
+		if err := e.jobs.DeleteJob(context.Background(), id); err != nil {
+			e.log.Error("failed to delete terminal async put job", "err", err)
+		}
+	})
+}
+
+func (e EigenDAStore) saveJob(ctx context.Context, jobID []byte, job *putJob) {
+	if err := e.jobs.SaveJob(ctx, jobID, job); err != nil {
+		e.log.Error("failed to persist async put job", "err", err)
+	}
+}
@@ -0,0 +1,67 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+)
+
+// BlobEncodingVersion identifies the scheme used to pack an EigenDA blob's
+// preimage into bn254 field elements. It is carried as the second byte of
+// the encoded blob's header (data[0] must stay 0 so the header itself is a
+// valid field element), which lets Get/Verify recover the codec a Put used
+// without any side-channel.
+type BlobEncodingVersion byte
+
+const (
+	// DefaultBlobEncoding is EigenDAClient's existing 32-byte-header,
+	// 31-byte-chunk encoding.
+	DefaultBlobEncoding BlobEncodingVersion = iota
+)
+
+// Codec encodes a preimage into an EigenDA blob and decodes it back.
+type Codec interface {
+	EncodeBlob(data []byte) ([]byte, error)
+	DecodeBlob(data []byte) ([]byte, error)
+}
+
+// codecRegistry maps a BlobEncodingVersion to the Codec that implements it,
+// so new encodings can be added without touching EigenDAStore. It's guarded
+// by codecRegistryMu since RegisterCodec runs at EigenDAStore construction
+// time (not only from init()), which can race with codecForVersion reads
+// from stores already serving traffic.
+var (
+	codecRegistryMu sync.RWMutex
+	codecRegistry   = map[BlobEncodingVersion]Codec{}
+)
+
+// RegisterCodec makes codec available for version. Typically called from
+// init() by packages implementing a new encoding (e.g. an IFFT-aware or more
+// densely field-element-packed codec), but safe to call at any time.
+func RegisterCodec(version BlobEncodingVersion, codec Codec) {
+	codecRegistryMu.Lock()
+	defer codecRegistryMu.Unlock()
+	codecRegistry[version] = codec
+}
+
+func codecForVersion(version BlobEncodingVersion) (Codec, error) {
+	codecRegistryMu.RLock()
+	defer codecRegistryMu.RUnlock()
+	codec, ok := codecRegistry[version]
+	if !ok {
+		return nil, fmt.Errorf("no codec registered for blob encoding version %d", version)
+	}
+	return codec, nil
+}
+
+// GenericDecodeBlob reads the encoding version out of an EigenDA blob's
+// codec header and decodes it with the matching registered Codec.
+func GenericDecodeBlob(data []byte) ([]byte, error) {
+	if len(data) < 2 {
+		return nil, fmt.Errorf("blob too short to contain a codec header: length %d", len(data))
+	}
+	codec, err := codecForVersion(BlobEncodingVersion(data[1]))
+	if err != nil {
+		return nil, err
+	}
+	return codec.DecodeBlob(data)
+}
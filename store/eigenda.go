@@ -2,12 +2,14 @@ package store
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigenda-proxy/verify"
 	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/cenkalti/backoff/v4"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 )
@@ -20,113 +22,197 @@ type EigenDAStoreConfig struct {
 
 	// total duration time that client waits for blob to confirm
 	StatusQueryTimeout time.Duration
+
+	// DefaultBlobEncodingVersion is the encoding Put uses when the caller
+	// doesn't request a specific BlobEncodingVersion.
+	DefaultBlobEncodingVersion BlobEncodingVersion
+
+	// NewVerifyCertBackOff builds the retry/backoff policy used while polling
+	// VerifyCert toward confirmation depth, one fresh instance per job so
+	// jobs don't share retry state. Defaults to exponential backoff with
+	// jitter, starting at 2s and capping at 12s, if nil.
+	NewVerifyCertBackOff func() backoff.BackOff
+
+	// MaxVerifyCertRetries caps the number of VerifyCert attempts per job,
+	// independent of StatusQueryTimeout. Zero means unbounded.
+	MaxVerifyCertRetries int
+}
+
+// encodedCertificate is the RLP-encoded form returned by Put and accepted by
+// Get/Verify. Wrapping the on-chain verify.Certificate with the
+// BlobEncodingVersion used at Put time means Get/Verify always decode with
+// the same codec Put encoded with, even after new encodings are registered.
+type encodedCertificate struct {
+	Cert    verify.Certificate
+	Version BlobEncodingVersion
 }
 
 // EigenDAStore does storage interactions and verifications for blobs with DA.
 type EigenDAStore struct {
-	client    *clients.EigenDAClient
-	verifier  *verify.Verifier
-	cfg       *EigenDAStoreConfig
-	log       log.Logger
-	wvmClient *WVMClient
+	client   *clients.EigenDAClient
+	verifier *verify.Verifier
+	cfg      *EigenDAStoreConfig
+	log      log.Logger
+	jobs     JobStore
+	// archives are the archival mirrors Put writes encoded blobs to, keyed by
+	// backend name (e.g. "wvm", "arweave", "s3"). GetBlobFromArchive/
+	// GetArchiveTxRef read back from archives[name].
+	archives map[string]ArchivalStore
+	metrics  *Metrics
+
+	// entries/reads back Stats(); they're *int64 rather than int64 so that
+	// EigenDAStore's value-receiver methods all share the same counters.
+	entries *int64
+	reads   *int64
+
+	// backoffs holds each pending job's VerifyCert retry schedule, keyed by
+	// job ID. It lives outside putJob because backoff.BackOff carries
+	// unexported state that can't round-trip through a JobStore.
+	backoffs *sync.Map
+
+	// jobLocks serializes advanceJob per job ID (keyed by job ID, *sync.Mutex
+	// values), so RunAsyncWorker's sweep and a blocking Put's own poll never
+	// run advanceJob for the same job concurrently.
+	jobLocks *sync.Map
 }
 
 var _ KeyGeneratedStore = (*EigenDAStore)(nil)
+var _ ArchivallyMirroredKeyGeneratedStore = (*EigenDAStore)(nil)
 
 func NewEigenDAStore(client *clients.EigenDAClient,
 	v *verify.Verifier, log log.Logger, cfg *EigenDAStoreConfig, wvmClient *WVMClient) (*EigenDAStore, error) {
-	return &EigenDAStore{
-		client:    client,
-		verifier:  v,
-		log:       log,
-		cfg:       cfg,
-		wvmClient: wvmClient,
-	}, nil
+	// the client's own codec backs the DefaultBlobEncoding version; codecs for
+	// any other registered BlobEncodingVersion are assumed to be registered by
+	// the caller before blobs encoded with them are read back.
+	RegisterCodec(DefaultBlobEncoding, client.GetCodec())
+
+	store := &EigenDAStore{
+		client:   client,
+		verifier: v,
+		log:      log,
+		cfg:      cfg,
+		jobs:     NewMemoryJobStore(),
+		archives: make(map[string]ArchivalStore),
+		entries:  new(int64),
+		reads:    new(int64),
+		backoffs: new(sync.Map),
+		jobLocks: new(sync.Map),
+	}
+	if wvmClient != nil {
+		store.archives["wvm"] = &wvmArchivalStore{client: wvmClient}
+	}
+	return store, nil
+}
+
+// WithJobStore overrides the JobStore used to persist PutAsync jobs, e.g. with
+// one backed by Redis or S3 so pending dispersals survive a proxy restart.
+// It must be called before any Put/PutAsync call.
+func (e *EigenDAStore) WithJobStore(jobs JobStore) *EigenDAStore {
+	e.jobs = jobs
+	return e
+}
+
+// RegisterArchive adds (or replaces) an archival mirror Put writes to
+// alongside any others already registered, reachable from Get by name.
+func (e *EigenDAStore) RegisterArchive(name string, archive ArchivalStore) *EigenDAStore {
+	e.archives[name] = archive
+	return e
+}
+
+// WithMetrics attaches a Metrics handle EigenDAStore publishes dispersal
+// latency, retries, and blob size observations to. Without it, EigenDAStore
+// runs with metrics disabled.
+func (e *EigenDAStore) WithMetrics(metrics *Metrics) *EigenDAStore {
+	e.metrics = metrics
+	return e
 }
 
 // Get fetches a blob from DA using certificate fields and verifies blob
 // against commitment to ensure data is valid and non-tampered.
 func (e EigenDAStore) Get(ctx context.Context, key []byte) ([]byte, error) {
-	var cert verify.Certificate
-	err := rlp.DecodeBytes(key, &cert)
+	var encCert encodedCertificate
+	err := rlp.DecodeBytes(key, &encCert)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode DA cert to RLP format: %w", err)
 	}
+	cert := encCert.Cert
 
-	decodedBlob, err := e.client.GetBlob(ctx, cert.BlobVerificationProof.BatchMetadata.BatchHeaderHash, cert.BlobVerificationProof.BlobIndex)
+	rawBlob, err := e.client.GetBlob(ctx, cert.BlobVerificationProof.BatchMetadata.BatchHeaderHash, cert.BlobVerificationProof.BlobIndex)
 	if err != nil {
 		return nil, fmt.Errorf("EigenDA client failed to retrieve decoded blob: %w", err)
 	}
 
-	return decodedBlob, nil
-}
-
-// Put disperses a blob for some pre-image and returns the associated RLP encoded certificate commit.
-func (e EigenDAStore) Put(ctx context.Context, value []byte) ([]byte, error) {
-	encodedBlob, err := e.client.GetCodec().EncodeBlob(value)
+	codec, err := codecForVersion(encCert.Version)
 	if err != nil {
-		return nil, fmt.Errorf("EigenDA client failed to re-encode blob: %w", err)
-	}
-	// WVM: check that the data is lower than 100kb - Set it in configs via proxy config
-	if uint64(len(encodedBlob)) > e.cfg.MaxBlobSizeBytes {
-		return nil, fmt.Errorf("encoded blob is larger than max blob size: blob length %d, encoded blob length: %d, max blob size %d", len(value), len(encodedBlob), e.cfg.MaxBlobSizeBytes)
+		return nil, err
 	}
-
-	dispersalStart := time.Now()
-	blobInfo, err := e.client.PutBlob(ctx, value)
+	decodedBlob, err := codec.DecodeBlob(rawBlob)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to decode blob with encoding version %d: %w", encCert.Version, err)
 	}
-	cert := (*verify.Certificate)(blobInfo)
 
-	err = e.verifier.VerifyCommitment(cert.BlobHeader.Commitment, encodedBlob)
+	atomic.AddInt64(e.reads, 1)
+	return decodedBlob, nil
+}
+
+// Put disperses a blob for some pre-image, encoded with cfg.DefaultBlobEncodingVersion,
+// and blocks until the resulting certificate is Finalized. It is a thin
+// wrapper around PutAsync/PutStatus kept for callers that want the older
+// blocking behavior; new integrations should prefer PutAsync.
+func (e EigenDAStore) Put(ctx context.Context, value []byte) ([]byte, error) {
+	return e.PutWithVersion(ctx, value, e.cfg.DefaultBlobEncodingVersion)
+}
+
+// PutWithVersion is Put, encoding value with the codec registered for version.
+func (e EigenDAStore) PutWithVersion(ctx context.Context, value []byte, version BlobEncodingVersion) ([]byte, error) {
+	jobID, err := e.PutAsyncWithVersion(ctx, value, version)
 	if err != nil {
 		return nil, err
 	}
 
-	dispersalDuration := time.Since(dispersalStart)
-	remainingTimeout := e.cfg.StatusQueryTimeout - dispersalDuration
-
-	ticker := time.NewTicker(12 * time.Second) // avg. eth block time
+	// Tick far more often than advanceJob's own VerifyCert schedule ever
+	// needs: advanceJob no-ops until job.nextAttempt() is due, so this just
+	// needs to be short enough that the backoff schedule (2s early, backing
+	// off later) actually governs polling cadence instead of a coarse ticker.
+	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
-	ctx, cancel := context.WithTimeout(context.Background(), remainingTimeout)
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.StatusQueryTimeout)
 	defer cancel()
 
-	done := false
-	for !done {
+	for {
 		select {
 		case <-ctx.Done():
 			return nil, fmt.Errorf("timed out when trying to verify the DA certificate for a blob batch after dispersal")
 		case <-ticker.C:
-			err = e.verifier.VerifyCert(cert)
-			switch {
-			case err == nil:
-				done = true
-			case errors.Is(err, verify.ErrBatchMetadataHashNotFound):
-				e.log.Info("Blob confirmed, waiting for sufficient confirmation depth...", "targetDepth", e.cfg.EthConfirmationDepth)
-			default:
+			// Advance only this job: RunAsyncWorker's single background
+			// worker already sweeps every pending job, so a blocking Put
+			// must not also trigger a global sweep (every concurrent Put
+			// would otherwise re-sweep all jobs). advanceJobByID's per-job
+			// lock keeps this in step with that worker instead of racing it.
+			e.advanceJobByID(ctx, jobID)
+			state, _, err := e.PutStatus(ctx, jobID)
+			if err != nil {
 				return nil, err
 			}
+			switch state {
+			case Finalized:
+				return jobID, nil
+			case Failed:
+				return nil, fmt.Errorf("blob dispersal failed")
+			case Confirmed:
+				e.log.Info("Blob confirmed, waiting for sufficient confirmation depth...", "targetDepth", e.cfg.EthConfirmationDepth)
+			}
 		}
 	}
-
-	// WVM: we store the encoded blob in wvm
-	err = e.wvmClient.Store(ctx, cert, encodedBlob)
-	if err != nil {
-		return nil, err
-	}
-
-	bytes, err := rlp.EncodeToBytes(cert)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encode DA cert to RLP format: %w", err)
-	}
-
-	return bytes, nil
 }
 
-// Entries are a no-op for EigenDA Store
+// Stats reports the number of blobs dispersed and read back through this
+// store, backed by atomic counters incremented by Put/PutAsync and Get.
 func (e EigenDAStore) Stats() *Stats {
-	return nil
+	return &Stats{
+		Entries: int(atomic.LoadInt64(e.entries)),
+		Reads:   int(atomic.LoadInt64(e.reads)),
+	}
 }
 
 // Backend returns the backend type for EigenDA Store
@@ -137,14 +223,20 @@ func (e EigenDAStore) BackendType() BackendType {
 // Key is used to recover certificate fields and that verifies blob
 // against commitment to ensure data is valid and non-tampered.
 func (e EigenDAStore) Verify(key []byte, value []byte) error {
-	var cert verify.Certificate
-	err := rlp.DecodeBytes(key, &cert)
+	var encCert encodedCertificate
+	err := rlp.DecodeBytes(key, &encCert)
 	if err != nil {
 		return fmt.Errorf("failed to decode DA cert to RLP format: %w", err)
 	}
+	cert := encCert.Cert
+
+	codec, err := codecForVersion(encCert.Version)
+	if err != nil {
+		return err
+	}
 
 	// re-encode blob for verification
-	encodedBlob, err := e.client.GetCodec().EncodeBlob(value)
+	encodedBlob, err := codec.EncodeBlob(value)
 	if err != nil {
 		return fmt.Errorf("EigenDA client failed to re-encode blob: %w", err)
 	}
@@ -159,44 +251,66 @@ func (e EigenDAStore) Verify(key []byte, value []byte) error {
 	return e.verifier.VerifyCert(&cert)
 }
 
-// GetWvmTxHashByCommitment uses commitment to get wvm tx hash from the internal map(temprorary hack)
-// and returns it to the caller
-func (e EigenDAStore) GetWvmTxHashByCommitment(ctx context.Context, key []byte) (string, error) {
-	e.log.Info("try get wvm tx hash using provided commitment")
-	var cert verify.Certificate
-	err := rlp.DecodeBytes(key, &cert)
+// archive looks up a registered ArchivalStore by name, erroring with the
+// requested name if it's not configured.
+func (e EigenDAStore) archive(name string) (ArchivalStore, error) {
+	archive, ok := e.archives[name]
+	if !ok {
+		return nil, fmt.Errorf("no archival backend registered named %q", name)
+	}
+	return archive, nil
+}
+
+// GetArchiveTxRef uses the commitment to get the named archive backend's
+// transaction/object reference and returns it to the caller.
+func (e EigenDAStore) GetArchiveTxRef(ctx context.Context, archive string, key []byte) (string, error) {
+	e.log.Info("try get archive tx ref using provided commitment", "archive", archive)
+	var encCert encodedCertificate
+	err := rlp.DecodeBytes(key, &encCert)
 	if err != nil {
 		return "", fmt.Errorf("failed to decode DA cert to RLP format: %w", err)
 	}
 
-	wvmTxHash, err := e.wvmClient.GetWvmTxHashByCommitment(ctx, &cert)
+	a, err := e.archive(archive)
+	if err != nil {
+		return "", err
+	}
+
+	txRef, err := a.TxRef(ctx, &encCert.Cert)
 	if err != nil {
 		return "", err
 	}
 
-	return wvmTxHash, nil
+	return txRef, nil
 }
 
-func (e EigenDAStore) GetBlobFromWvm(ctx context.Context, key []byte) ([]byte, error) {
-	var cert verify.Certificate
-	err := rlp.DecodeBytes(key, &cert)
+// GetBlobFromArchive retrieves and decodes the blob committed to by key
+// directly from the named archive backend, bypassing EigenDA.
+func (e EigenDAStore) GetBlobFromArchive(ctx context.Context, archive string, key []byte) ([]byte, error) {
+	var encCert encodedCertificate
+	err := rlp.DecodeBytes(key, &encCert)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode DA cert to RLP format: %w", err)
 	}
+	cert := encCert.Cert
 
-	wvmTxHash, err := e.wvmClient.GetWvmTxHashByCommitment(ctx, &cert)
+	a, err := e.archive(archive)
 	if err != nil {
 		return nil, err
 	}
 
-	e.log.Info("found wvm tx hash using provided commitment", "provided key", commitmentKey(cert.BlobVerificationProof.BatchId, cert.BlobVerificationProof.BlobIndex))
+	e.log.Info("found archived blob using provided commitment", "archive", archive, "provided key", commitmentKey(cert.BlobVerificationProof.BatchId, cert.BlobVerificationProof.BlobIndex))
 
-	wvmDecodedBlob, err := e.wvmClient.GetBlobFromWvm(ctx, wvmTxHash)
+	archivedBlob, err := a.Lookup(ctx, &cert)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get eigenda blob from wvm: %w", err)
+		return nil, fmt.Errorf("failed to get eigenda blob from %s archive: %w", archive, err)
 	}
 
-	decodedData, err := e.client.Codec.DecodeBlob(wvmDecodedBlob)
+	// Decode using the version carried in the archived blob's own codec
+	// header rather than encCert.Version: the archive is an external system,
+	// so the bytes it hands back should be self-describing rather than
+	// trusting the RLP cert wrapper's version field.
+	decodedData, err := GenericDecodeBlob(archivedBlob)
 	if err != nil {
 		return nil, fmt.Errorf("error decoding eigen blob: %w", err)
 	}
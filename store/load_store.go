@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Config describes which DA backends are enabled for this proxy instance and
+// how MultiDAStore should order Put fallback between them when more than one
+// is enabled.
+type Config struct {
+	// Enabled lists every backend type this proxy instance should disperse
+	// to and read from.
+	Enabled []BackendType
+	// MultiDA is only consulted when len(Enabled) > 1.
+	MultiDA MultiDAStoreConfig
+}
+
+// BackendFactory constructs the KeyGeneratedStore for a single backend type.
+// Callers register one per BackendType they want load_store to be able to
+// build.
+type BackendFactory func(ctx context.Context) (KeyGeneratedStore, error)
+
+// LoadStore builds the KeyGeneratedStore for the given Config out of the
+// provided factories. When more than one backend is enabled it returns a
+// MultiDAStore fanning out across all of them; when exactly one is enabled it
+// returns that backend's store directly so single-DA deployments don't pay
+// the MultiDAStore indirection.
+func LoadStore(ctx context.Context, cfg Config, factories map[BackendType]BackendFactory, log log.Logger) (KeyGeneratedStore, error) {
+	if len(cfg.Enabled) == 0 {
+		return nil, fmt.Errorf("no DA backends enabled")
+	}
+
+	backends := make(map[BackendType]KeyGeneratedStore, len(cfg.Enabled))
+	for _, bt := range cfg.Enabled {
+		factory, ok := factories[bt]
+		if !ok {
+			return nil, fmt.Errorf("no factory registered for enabled backend type %s", bt)
+		}
+		backend, err := factory(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s backend: %w", bt, err)
+		}
+		backends[bt] = backend
+	}
+
+	if len(backends) == 1 {
+		return backends[cfg.Enabled[0]], nil
+	}
+
+	return NewMultiDAStore(cfg.MultiDA, backends, log)
+}
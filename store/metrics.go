@@ -0,0 +1,112 @@
+package store
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// putPhase labels which phase of EigenDAStore.Put/PutAsync a duration
+// measurement belongs to.
+type putPhase string
+
+const (
+	phaseEncode           putPhase = "encode"
+	phaseDisperse         putPhase = "disperse"
+	phaseVerifyCommitment putPhase = "verify_commitment"
+	phaseVerifyCert       putPhase = "verify_cert"
+	phaseWvmMirror        putPhase = "wvm_mirror"
+)
+
+// Metrics holds the Prometheus collectors EigenDAStore publishes to.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	putDuration           *prometheus.HistogramVec
+	confirmationDepthWait prometheus.Histogram
+	blobBytes             prometheus.Histogram
+	verifyCertRetries     prometheus.Counter
+	errorsTotal           *prometheus.CounterVec
+}
+
+// NewMetrics registers the store package's collectors against registry and
+// returns a Metrics handle for EigenDAStore to publish to.
+func NewMetrics(registry *prometheus.Registry) *Metrics {
+	m := &Metrics{
+		registry: registry,
+		putDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "eigenda_put_duration_seconds",
+			Help:    "Duration of each phase of dispersing a blob through EigenDAStore, labeled by phase.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"phase"}),
+		confirmationDepthWait: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "eigenda_confirmation_depth_wait_seconds",
+			Help:    "Time spent waiting for a dispersed blob to reach the configured Ethereum confirmation depth.",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+		}),
+		blobBytes: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "eigenda_blob_bytes",
+			Help:    "Size, in bytes, of encoded blobs dispersed through EigenDAStore.",
+			Buckets: prometheus.ExponentialBuckets(256, 2, 16),
+		}),
+		verifyCertRetries: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "eigenda_verify_cert_retries_total",
+			Help: "Number of times VerifyCert reported the blob confirmed but not yet at the required confirmation depth.",
+		}),
+		errorsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "eigenda_errors_total",
+			Help: "Count of EigenDAStore errors, labeled by whether they were a not-yet-at-depth wait or another failure mode.",
+		}, []string{"kind"}),
+	}
+	registry.MustRegister(m.putDuration, m.confirmationDepthWait, m.blobBytes, m.verifyCertRetries, m.errorsTotal)
+	return m
+}
+
+// Handler serves the metrics registered in NewMetrics's registry. Mount it at
+// /metrics in the HTTP server.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) observePutDuration(phase putPhase, d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.putDuration.WithLabelValues(string(phase)).Observe(d.Seconds())
+}
+
+func (m *Metrics) observeConfirmationDepthWait(d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.confirmationDepthWait.Observe(d.Seconds())
+}
+
+func (m *Metrics) observeBlobBytes(n int) {
+	if m == nil {
+		return
+	}
+	m.blobBytes.Observe(float64(n))
+}
+
+func (m *Metrics) incVerifyCertRetry() {
+	if m == nil {
+		return
+	}
+	m.verifyCertRetries.Inc()
+}
+
+func (m *Metrics) recordError(err error) {
+	if m == nil || err == nil {
+		return
+	}
+	if errors.Is(err, verify.ErrBatchMetadataHashNotFound) {
+		m.errorsTotal.WithLabelValues("batch_metadata_hash_not_found").Inc()
+		return
+	}
+	m.errorsTotal.WithLabelValues("other").Inc()
+}
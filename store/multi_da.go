@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// MultiDAStoreConfig configures which backends MultiDAStore disperses to and
+// in what order Put falls back across them.
+type MultiDAStoreConfig struct {
+	// Primary is the backend Put tries first.
+	Primary BackendType
+	// FallbackOrder lists the backends Put falls back to, in order, when
+	// Primary (or an earlier fallback) fails or times out.
+	FallbackOrder []BackendType
+}
+
+// MultiDAStore fans a single logical Put out across multiple configured DA
+// backends, falling back to the next configured backend when the current one
+// fails or times out, and routes Get/Verify back to whichever backend
+// produced a given commitment using its leading prefix byte.
+type MultiDAStore struct {
+	cfg      MultiDAStoreConfig
+	backends map[BackendType]KeyGeneratedStore
+	log      log.Logger
+}
+
+var _ KeyGeneratedStore = (*MultiDAStore)(nil)
+
+// NewMultiDAStore builds a MultiDAStore from a set of already-constructed
+// backend stores keyed by BackendType. cfg.Primary must have a corresponding
+// entry in backends.
+func NewMultiDAStore(cfg MultiDAStoreConfig, backends map[BackendType]KeyGeneratedStore, log log.Logger) (*MultiDAStore, error) {
+	if _, ok := backends[cfg.Primary]; !ok {
+		return nil, fmt.Errorf("no backend configured for primary backend type %s", cfg.Primary)
+	}
+	return &MultiDAStore{
+		cfg:      cfg,
+		backends: backends,
+		log:      log,
+	}, nil
+}
+
+// order returns Primary followed by FallbackOrder as configured; it does not
+// filter out backend types with no store configured (Put skips those).
+func (m *MultiDAStore) order() []BackendType {
+	order := make([]BackendType, 0, len(m.cfg.FallbackOrder)+1)
+	order = append(order, m.cfg.Primary)
+	order = append(order, m.cfg.FallbackOrder...)
+	return order
+}
+
+// Put disperses value to the primary backend, falling back to the next
+// configured backend in order when dispersal fails. The returned key is the
+// winning backend's key prefixed with a byte identifying which backend
+// produced it, so Get can route directly back to it.
+func (m *MultiDAStore) Put(ctx context.Context, value []byte) ([]byte, error) {
+	var lastErr error
+	for _, bt := range m.order() {
+		backend, ok := m.backends[bt]
+		if !ok {
+			continue
+		}
+		key, err := backend.Put(ctx, value)
+		if err != nil {
+			m.log.Warn("backend failed to disperse blob, falling back to next configured backend", "backend", bt, "err", err)
+			lastErr = err
+			continue
+		}
+		return append([]byte{backendPrefix(bt)}, key...), nil
+	}
+	return nil, fmt.Errorf("all configured DA backends failed to disperse blob: %w", lastErr)
+}
+
+// Get reads the commitment's leading prefix byte to determine which backend
+// produced it and retrieves directly from that backend.
+func (m *MultiDAStore) Get(ctx context.Context, key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, fmt.Errorf("commitment is empty, cannot determine originating backend")
+	}
+	bt := BackendType(key[0])
+	backend, ok := m.backends[bt]
+	if !ok {
+		return nil, fmt.Errorf("no backend configured to serve commitments produced by backend type %s", bt)
+	}
+	return backend.Get(ctx, key[1:])
+}
+
+// Verify delegates to the backend identified by the commitment's leading
+// prefix byte.
+func (m *MultiDAStore) Verify(key []byte, value []byte) error {
+	if len(key) == 0 {
+		return fmt.Errorf("commitment is empty, cannot determine originating backend")
+	}
+	bt := BackendType(key[0])
+	backend, ok := m.backends[bt]
+	if !ok {
+		return fmt.Errorf("no backend configured to verify commitments produced by backend type %s", bt)
+	}
+	return backend.Verify(key[1:], value)
+}
+
+// Stats is a no-op for MultiDAStore; query the underlying backends directly.
+func (m *MultiDAStore) Stats() *Stats {
+	return nil
+}
+
+// BackendType returns the primary backend type configured for this store.
+func (m *MultiDAStore) BackendType() BackendType {
+	return m.cfg.Primary
+}
@@ -9,6 +9,8 @@ const (
 	Memory
 	S3
 	Redis
+	Celestia
+	Avail
 
 	Unknown
 )
@@ -23,6 +25,10 @@ func (b BackendType) String() string {
 		return "S3"
 	case Redis:
 		return "Redis"
+	case Celestia:
+		return "Celestia"
+	case Avail:
+		return "Avail"
 	case Unknown:
 		fallthrough
 	default:
@@ -40,6 +46,10 @@ func StringToBackendType(s string) BackendType {
 		return S3
 	case "Redis":
 		return Redis
+	case "Celestia":
+		return Celestia
+	case "Avail":
+		return Avail
 	case "Unknown":
 		fallthrough
 	default:
@@ -47,6 +57,18 @@ func StringToBackendType(s string) BackendType {
 	}
 }
 
+// commitmentPrefix is prepended to every key returned by MultiDAStore so that a
+// later Get can route directly to the backend that produced the commitment
+// without trying each configured backend in turn.
+type commitmentPrefix = byte
+
+// backendPrefix maps a BackendType to the single byte stamped at the front of
+// its commitments: the BackendType's own numeric value, for every type
+// including Unknown.
+func backendPrefix(b BackendType) commitmentPrefix {
+	return byte(b)
+}
+
 // Used for E2E tests
 type Stats struct {
 	Entries int
@@ -70,10 +92,18 @@ type KeyGeneratedStore interface {
 	Put(ctx context.Context, value []byte) (key []byte, err error)
 }
 
-type WVMedKeyGeneratedStore interface {
+// ArchivallyMirroredKeyGeneratedStore is a KeyGeneratedStore that also
+// mirrors every Put to one or more named ArchivalStore backends (e.g. "wvm",
+// "arweave", "s3") and can serve a Get directly from a named backend, e.g. so
+// an HTTP handler can answer `/get/<cert>?archive=wvm`.
+type ArchivallyMirroredKeyGeneratedStore interface {
 	KeyGeneratedStore
-	GetWvmTxHashByCommitment(ctx context.Context, key []byte) (string, error)
-	GetBlobFromWvm(ctx context.Context, key []byte) ([]byte, error)
+	// GetArchiveTxRef returns the named archive backend's transaction/object
+	// reference for the blob committed to by key.
+	GetArchiveTxRef(ctx context.Context, archive string, key []byte) (string, error)
+	// GetBlobFromArchive retrieves and decodes the blob committed to by key
+	// directly from the named archive backend, bypassing EigenDA.
+	GetBlobFromArchive(ctx context.Context, archive string, key []byte) ([]byte, error)
 }
 
 type PrecomputedKeyStore interface {
@@ -82,4 +112,6 @@ type PrecomputedKeyStore interface {
 	Get(ctx context.Context, key []byte) ([]byte, error)
 	// Put inserts the given value into the key-value data store.
 	Put(ctx context.Context, key []byte, value []byte) error
+	// Delete removes the given key from the key-value data store, if present.
+	Delete(ctx context.Context, key []byte) error
 }